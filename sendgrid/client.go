@@ -0,0 +1,244 @@
+package sendgrid
+
+import (
+    "context"
+    "math/rand"
+    "net/http"
+    "strconv"
+    "strings"
+    "sync"
+    "time"
+
+    "github.com/sendgrid/rest"
+    "github.com/sendgrid/sendgrid-go"
+    "golang.org/x/time/rate"
+)
+
+const sendgridAddress = "https://api.sendgrid.com"
+
+const (
+    defaultRateLimitQPS   = 0.2 // one request every 5s, matching the limits this replaces
+    defaultRateLimitBurst = 1
+    defaultMaxRetries     = 5
+)
+
+// Config holds the provider-level configuration shared by every resource
+// and data source in this package. RateLimitQPS/RateLimitBurst come from
+// the provider's rate_limit_qps/rate_limit_burst arguments; a zero value
+// falls back to the package defaults.
+type Config struct {
+    APIKey string
+
+    RateLimitQPS   float64
+    RateLimitBurst int
+
+    limitersMu sync.Mutex
+    limiters   map[string]*rate.Limiter
+}
+
+const (
+    statusWaiting = "waiting"
+    statusDone    = "done"
+)
+
+var defaultBackoff = 5 * time.Second
+
+// limiterFor returns the shared rate.Limiter for the given endpoint
+// category (e.g. "templates.create"), lazily creating it with the
+// configured (or default) QPS/burst. Limiters live on Config, so they are
+// shared across requests made with the same API key instead of applying
+// per-process like the time.Tick channels they replace.
+func (c *Config) limiterFor(category string) *rate.Limiter {
+    c.limitersMu.Lock()
+    defer c.limitersMu.Unlock()
+
+    if c.limiters == nil {
+        c.limiters = make(map[string]*rate.Limiter)
+    }
+
+    limiter, ok := c.limiters[category]
+    if !ok {
+        qps := c.RateLimitQPS
+        if qps <= 0 {
+            qps = defaultRateLimitQPS
+        }
+
+        burst := c.RateLimitBurst
+        if burst <= 0 {
+            burst = defaultRateLimitBurst
+        }
+
+        limiter = rate.NewLimiter(rate.Limit(qps), burst)
+        c.limiters[category] = limiter
+    }
+
+    return limiter
+}
+
+// RateLimiterState reports a category's current limiter so refresh loops
+// (e.g. resource.StateChangeConf) can size their own polling delay instead
+// of relying on a dedicated rate-limit error type.
+type RateLimiterState struct {
+    Limit  rate.Limit
+    Burst  int
+    Tokens float64
+}
+
+// RateLimiterState returns the current state of category's token bucket.
+func (c *Config) RateLimiterState(category string) RateLimiterState {
+    limiter := c.limiterFor(category)
+
+    return RateLimiterState{
+        Limit:  limiter.Limit(),
+        Burst:  limiter.Burst(),
+        Tokens: limiter.Tokens(),
+    }
+}
+
+type requestOptions struct {
+    statuses []int
+    category string
+    retries  int
+}
+
+type requestOption func(*requestOptions)
+
+// withStatus marks a response status code as an expected, non-error outcome
+// of the request. Multiple statuses can be accepted by passing withStatus
+// more than once.
+func withStatus(status int) requestOption {
+    return func(o *requestOptions) {
+        o.statuses = append(o.statuses, status)
+    }
+}
+
+// withCategory assigns the request to a named rate limiter bucket, e.g.
+// "templates.create" or "templates.versions.delete". Requests without a
+// category share the "default" bucket.
+func withCategory(category string) requestOption {
+    return func(o *requestOptions) {
+        o.category = category
+    }
+}
+
+// withRetry caps how many times a 429 response is retried before doRequest
+// gives up and returns a *SendGridAPIError.
+func withRetry(n int) requestOption {
+    return func(o *requestOptions) {
+        o.retries = n
+    }
+}
+
+// doRequest sends request through the sendgrid-go client, gated by the
+// Config's shared token bucket for the request's category. On a 429
+// response it sleeps until the later of the Retry-After and
+// X-RateLimit-Reset headers before retrying, falling back to exponential
+// backoff when neither header is present, and adds a small jitter to the
+// wait either way. Any other
+// unexpected status (not passed to withStatus) comes back as a
+// *SendGridAPIError.
+func (c *Config) doRequest(request rest.Request, opts ...requestOption) (*rest.Response, error) {
+    options := &requestOptions{category: "default", retries: defaultMaxRetries}
+    for _, opt := range opts {
+        opt(options)
+    }
+
+    limiter := c.limiterFor(options.category)
+
+    var res *rest.Response
+    var err error
+
+    for attempt := 0; ; attempt++ {
+        if err := limiter.Wait(context.Background()); err != nil {
+            return nil, err
+        }
+
+        res, err = sendgrid.API(request)
+        if err != nil {
+            return nil, err
+        }
+
+        if res.StatusCode != http.StatusTooManyRequests {
+            break
+        }
+
+        if attempt >= options.retries {
+            return res, newSendGridAPIError(res)
+        }
+
+        time.Sleep(rateLimitBackoff(res.Headers, attempt))
+    }
+
+    for _, status := range options.statuses {
+        if res.StatusCode == status {
+            return res, nil
+        }
+    }
+
+    return res, newSendGridAPIError(res)
+}
+
+// rateLimitBackoff picks how long to sleep before retrying a 429 response:
+// the later of Retry-After and X-RateLimit-Reset when SendGrid sent either,
+// otherwise exponential backoff from defaultBackoff. A small jitter is
+// added so retries from concurrent requests don't line back up.
+func rateLimitBackoff(headers map[string][]string, attempt int) time.Duration {
+    wait := retryAfterDuration(headers)
+    if reset := rateLimitResetDuration(headers); reset > wait {
+        wait = reset
+    }
+
+    if wait <= 0 {
+        wait = defaultBackoff * time.Duration(int64(1)<<uint(attempt))
+    }
+
+    return wait + time.Duration(rand.Int63n(int64(wait)/4+1))
+}
+
+// retryAfterDuration parses Retry-After in either of the two forms the HTTP
+// spec allows: a number of seconds, or an HTTP-date.
+func retryAfterDuration(headers map[string][]string) time.Duration {
+    value := headerValue(headers, "Retry-After")
+    if value == "" {
+        return 0
+    }
+
+    if seconds, err := strconv.Atoi(value); err == nil {
+        return time.Duration(seconds) * time.Second
+    }
+
+    if when, err := http.ParseTime(value); err == nil {
+        return time.Until(when)
+    }
+
+    return 0
+}
+
+// rateLimitResetDuration parses the X-RateLimit-Reset header, a Unix epoch
+// in seconds at which the current rate-limit window resets.
+func rateLimitResetDuration(headers map[string][]string) time.Duration {
+    value := headerValue(headers, "X-Ratelimit-Reset")
+    if value == "" {
+        return 0
+    }
+
+    epoch, err := strconv.ParseInt(value, 10, 64)
+    if err != nil {
+        return 0
+    }
+
+    return time.Until(time.Unix(epoch, 0))
+}
+
+// headerValue returns the first value of key in headers, matching
+// case-insensitively since proxies and test doubles don't always send
+// canonically-cased header names.
+func headerValue(headers map[string][]string, key string) string {
+    for name, values := range headers {
+        if len(values) > 0 && strings.EqualFold(name, key) {
+            return values[0]
+        }
+    }
+
+    return ""
+}
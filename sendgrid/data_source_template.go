@@ -0,0 +1,119 @@
+package sendgrid
+
+import (
+    "fmt"
+
+    "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+    "github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+)
+
+// dataSourceTemplate looks up a single existing template by id or name so
+// Terraform configuration doesn't have to hardcode the template's opaque
+// ID.
+func dataSourceTemplate() *schema.Resource {
+    return &schema.Resource{
+        Read: dataSourceTemplateRead,
+        Schema: map[string]*schema.Schema{
+            "id": &schema.Schema{
+                Type:     schema.TypeString,
+                Optional: true,
+                Computed: true,
+            },
+            "name": &schema.Schema{
+                Type:     schema.TypeString,
+                Optional: true,
+                Computed: true,
+            },
+            "generation": &schema.Schema{
+                Type:         schema.TypeString,
+                Optional:     true,
+                ValidateFunc: validation.StringInSlice([]string{"legacy", "dynamic"}, false),
+            },
+            "versions": &schema.Schema{
+                Type:     schema.TypeList,
+                Computed: true,
+                Elem: &schema.Resource{
+                    Schema: map[string]*schema.Schema{
+                        "id": &schema.Schema{
+                            Type:     schema.TypeString,
+                            Computed: true,
+                        },
+                        "name": &schema.Schema{
+                            Type:     schema.TypeString,
+                            Computed: true,
+                        },
+                        "subject": &schema.Schema{
+                            Type:     schema.TypeString,
+                            Computed: true,
+                        },
+                        "html_content": &schema.Schema{
+                            Type:     schema.TypeString,
+                            Computed: true,
+                        },
+                        "plain_content": &schema.Schema{
+                            Type:     schema.TypeString,
+                            Computed: true,
+                        },
+                        "active": &schema.Schema{
+                            Type:     schema.TypeInt,
+                            Computed: true,
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+func dataSourceTemplateRead(d *schema.ResourceData, m interface{}) error {
+    cfg := m.(*Config)
+
+    id := d.Get("id").(string)
+    name := d.Get("name").(string)
+
+    if id == "" && name == "" {
+        return fmt.Errorf("one of id or name must be set")
+    }
+
+    var t *template
+
+    if id != "" {
+        found, err := getTemplate(cfg, id)
+        if err != nil {
+            return err
+        }
+
+        t = found
+    } else {
+        templates, err := listAllTemplates(cfg, d.Get("generation").(string))
+        if err != nil {
+            return err
+        }
+
+        var matches []template
+        for _, candidate := range templates {
+            if candidate.Name == name {
+                matches = append(matches, candidate)
+            }
+        }
+
+        switch len(matches) {
+        case 0:
+            return fmt.Errorf("no sendgrid template found with name %q", name)
+        case 1:
+            t = &matches[0]
+        default:
+            return fmt.Errorf("found %d sendgrid templates named %q, use id to disambiguate", len(matches), name)
+        }
+    }
+
+    if t == nil {
+        return fmt.Errorf("sendgrid template %q not found", id)
+    }
+
+    d.SetId(t.TemplateID)
+    d.Set("name", t.Name)
+    d.Set("versions", flattenVersions(t.Versions))
+
+    return nil
+}
@@ -0,0 +1,191 @@
+package sendgrid
+
+import (
+    "encoding/json"
+    "net/http"
+    "net/url"
+    "strconv"
+
+    "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+    "github.com/hashicorp/terraform-plugin-sdk/helper/validation"
+    "github.com/pkg/errors"
+    "github.com/sendgrid/sendgrid-go"
+)
+
+// listTemplatesPageSize is the page_size sent on every /v3/templates list
+// request; 200 is the maximum SendGrid allows.
+const listTemplatesPageSize = 200
+
+// allTemplateGenerations is sent when no generation filter is requested.
+// SendGrid's GET /v3/templates defaults to generations=legacy when the
+// param is omitted entirely, which would silently hide dynamic templates.
+const allTemplateGenerations = "legacy,dynamic"
+
+type templateList struct {
+    Result   []template       `json:"result"`
+    Metadata templateListMeta `json:"_metadata"`
+}
+
+type templateListMeta struct {
+    Self  string `json:"self"`
+    Next  string `json:"next"`
+    Prev  string `json:"prev"`
+    Count int    `json:"count"`
+}
+
+// dataSourceTemplates lists every template in the account, for use with
+// for_each. It shares listAllTemplates with dataSourceTemplate; results are
+// not cached, so each read of either data source walks every page again.
+func dataSourceTemplates() *schema.Resource {
+    return &schema.Resource{
+        Read: dataSourceTemplatesRead,
+        Schema: map[string]*schema.Schema{
+            "generation": &schema.Schema{
+                Type:         schema.TypeString,
+                Optional:     true,
+                ValidateFunc: validation.StringInSlice([]string{"legacy", "dynamic"}, false),
+            },
+            "templates": &schema.Schema{
+                Type:     schema.TypeList,
+                Computed: true,
+                Elem: &schema.Resource{
+                    Schema: map[string]*schema.Schema{
+                        "id": &schema.Schema{
+                            Type:     schema.TypeString,
+                            Computed: true,
+                        },
+                        "name": &schema.Schema{
+                            Type:     schema.TypeString,
+                            Computed: true,
+                        },
+                        "versions": &schema.Schema{
+                            Type:     schema.TypeList,
+                            Computed: true,
+                            Elem: &schema.Resource{
+                                Schema: map[string]*schema.Schema{
+                                    "id": &schema.Schema{
+                                        Type:     schema.TypeString,
+                                        Computed: true,
+                                    },
+                                    "name": &schema.Schema{
+                                        Type:     schema.TypeString,
+                                        Computed: true,
+                                    },
+                                    "subject": &schema.Schema{
+                                        Type:     schema.TypeString,
+                                        Computed: true,
+                                    },
+                                    "html_content": &schema.Schema{
+                                        Type:     schema.TypeString,
+                                        Computed: true,
+                                    },
+                                    "plain_content": &schema.Schema{
+                                        Type:     schema.TypeString,
+                                        Computed: true,
+                                    },
+                                    "active": &schema.Schema{
+                                        Type:     schema.TypeInt,
+                                        Computed: true,
+                                    },
+                                },
+                            },
+                        },
+                    },
+                },
+            },
+        },
+    }
+}
+
+func dataSourceTemplatesRead(d *schema.ResourceData, m interface{}) error {
+    cfg := m.(*Config)
+    generation := d.Get("generation").(string)
+
+    templates, err := listAllTemplates(cfg, generation)
+    if err != nil {
+        return err
+    }
+
+    items := make([]interface{}, len(templates))
+    for i, t := range templates {
+        items[i] = map[string]interface{}{
+            "id":       t.TemplateID,
+            "name":     t.Name,
+            "versions": flattenVersions(t.Versions),
+        }
+    }
+
+    if generation == "" {
+        generation = "all"
+    }
+    d.SetId(generation)
+
+    return d.Set("templates", items)
+}
+
+// listAllTemplates walks /v3/templates a page at a time, following
+// _metadata.next until it is absent, and returns every template in the
+// account (optionally filtered by generation).
+func listAllTemplates(cfg *Config, generation string) ([]template, error) {
+    var all []template
+
+    pageToken := ""
+    for {
+        generations := generation
+        if generations == "" {
+            generations = allTemplateGenerations
+        }
+
+        query := map[string]string{
+            "page_size":   strconv.Itoa(listTemplatesPageSize),
+            "generations": generations,
+        }
+        if pageToken != "" {
+            query["page_token"] = pageToken
+        }
+
+        request := sendgrid.GetRequest(cfg.APIKey, "/v3/templates", sendgridAddress)
+        request.Method = http.MethodGet
+        request.QueryParams = query
+
+        res, err := cfg.doRequest(request, withStatus(http.StatusOK), withCategory("templates.list"))
+        if err != nil {
+            return nil, err
+        }
+
+        var page templateList
+        if err := json.Unmarshal([]byte(res.Body), &page); err != nil {
+            return nil, errors.Wrap(err, "failed to unmarshal template list response")
+        }
+
+        all = append(all, page.Result...)
+
+        next, err := nextPageToken(page.Metadata.Next)
+        if err != nil {
+            return nil, errors.Wrap(err, "failed to parse template list pagination")
+        }
+
+        if next == "" {
+            break
+        }
+
+        pageToken = next
+    }
+
+    return all, nil
+}
+
+// nextPageToken extracts the page_token query parameter from the full URL
+// SendGrid returns in _metadata.next.
+func nextPageToken(next string) (string, error) {
+    if next == "" {
+        return "", nil
+    }
+
+    u, err := url.Parse(next)
+    if err != nil {
+        return "", err
+    }
+
+    return u.Query().Get("page_token"), nil
+}
@@ -0,0 +1,89 @@
+package sendgrid
+
+import (
+    "encoding/json"
+    "fmt"
+    "net/http"
+    "strings"
+
+    "github.com/sendgrid/rest"
+)
+
+// SendGridFieldError is a single entry of the field-level validation errors
+// SendGrid returns in the body of most 4xx responses, e.g.
+// {"errors": [{"field": "subject", "message": "...", "error_id": "...", "help": "..."}]}.
+type SendGridFieldError struct {
+    Field   string `json:"field"`
+    Message string `json:"message"`
+    ErrorID string `json:"error_id"`
+    Help    string `json:"help"`
+}
+
+// SendGridAPIError wraps a non-2xx response from the SendGrid API. It keeps
+// the status code, raw body and decoded field errors around instead of
+// discarding them behind a generic errors.Wrap(err, "failed to ...") string.
+type SendGridAPIError struct {
+    StatusCode int
+    Body       string
+    Errors     []SendGridFieldError
+}
+
+type sendGridErrorBody struct {
+    Errors []SendGridFieldError `json:"errors"`
+}
+
+// newSendGridAPIError builds a SendGridAPIError from a non-accepted
+// response, decoding the body's errors array when present.
+func newSendGridAPIError(res *rest.Response) *SendGridAPIError {
+    apiErr := &SendGridAPIError{
+        StatusCode: res.StatusCode,
+        Body:       res.Body,
+    }
+
+    var body sendGridErrorBody
+    if err := json.Unmarshal([]byte(res.Body), &body); err == nil {
+        apiErr.Errors = body.Errors
+    }
+
+    return apiErr
+}
+
+func (e *SendGridAPIError) Error() string {
+    if len(e.Errors) == 0 {
+        return fmt.Sprintf("sendgrid API returned status %d: %s", e.StatusCode, e.Body)
+    }
+
+    messages := make([]string, len(e.Errors))
+    for i, fieldErr := range e.Errors {
+        if fieldErr.Field != "" {
+            messages[i] = fmt.Sprintf("%s: %s", fieldErr.Field, fieldErr.Message)
+        } else {
+            messages[i] = fieldErr.Message
+        }
+    }
+
+    return fmt.Sprintf("sendgrid API returned status %d: %s", e.StatusCode, strings.Join(messages, "; "))
+}
+
+// IsNotFound reports whether err is a SendGridAPIError for a 404 response.
+func IsNotFound(err error) bool {
+    apiErr, ok := err.(*SendGridAPIError)
+    return ok && apiErr.StatusCode == http.StatusNotFound
+}
+
+// IsRateLimited reports whether err is a SendGridAPIError for a 429 response.
+func IsRateLimited(err error) bool {
+    apiErr, ok := err.(*SendGridAPIError)
+    return ok && apiErr.StatusCode == http.StatusTooManyRequests
+}
+
+// FieldErrors returns the field-level validation errors carried by err, or
+// nil if err is not a *SendGridAPIError or carries none.
+func FieldErrors(err error) []SendGridFieldError {
+    apiErr, ok := err.(*SendGridAPIError)
+    if !ok {
+        return nil
+    }
+
+    return apiErr.Errors
+}
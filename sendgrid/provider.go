@@ -0,0 +1,49 @@
+package sendgrid
+
+import (
+    "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
+)
+
+// Provider returns the sendgrid Terraform provider: its resources, data
+// sources, and the arguments that populate Config for all of them.
+func Provider() *schema.Provider {
+    return &schema.Provider{
+        Schema: map[string]*schema.Schema{
+            "api_key": &schema.Schema{
+                Type:        schema.TypeString,
+                Required:    true,
+                Sensitive:   true,
+                DefaultFunc: schema.EnvDefaultFunc("SENDGRID_API_KEY", nil),
+            },
+            "rate_limit_qps": &schema.Schema{
+                Type:        schema.TypeFloat,
+                Optional:    true,
+                Default:     defaultRateLimitQPS,
+                Description: "Requests per second allowed per endpoint category before this provider starts waiting on SendGrid's rate limits.",
+            },
+            "rate_limit_burst": &schema.Schema{
+                Type:        schema.TypeInt,
+                Optional:    true,
+                Default:     defaultRateLimitBurst,
+                Description: "Number of requests allowed to burst above rate_limit_qps before this provider starts waiting.",
+            },
+        },
+        ResourcesMap: map[string]*schema.Resource{
+            "sendgrid_template":         resourceTemplate(),
+            "sendgrid_template_version": resourceTemplateVersion(),
+        },
+        DataSourcesMap: map[string]*schema.Resource{
+            "sendgrid_template":  dataSourceTemplate(),
+            "sendgrid_templates": dataSourceTemplates(),
+        },
+        ConfigureFunc: providerConfigure,
+    }
+}
+
+func providerConfigure(d *schema.ResourceData) (interface{}, error) {
+    return &Config{
+        APIKey:         d.Get("api_key").(string),
+        RateLimitQPS:   d.Get("rate_limit_qps").(float64),
+        RateLimitBurst: d.Get("rate_limit_burst").(int),
+    }, nil
+}
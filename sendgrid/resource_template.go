@@ -3,10 +3,8 @@ package sendgrid
 import (
         "encoding/json"
         "fmt"
-        "log"
         "net/http"
         "strings"
-        "time"
 
         "github.com/hashicorp/terraform-plugin-sdk/helper/resource"
         "github.com/hashicorp/terraform-plugin-sdk/helper/schema"
@@ -14,11 +12,6 @@ import (
         "github.com/sendgrid/sendgrid-go"
 )
 
-var (
-    createTemplateRate = time.Tick(5 * time.Second)
-    deleteTemplateRate = time.Tick(5 * time.Second)
-)
-
 type template struct {
     Name        string `json:"name"`
     TemplateID  string `json:"id"`
@@ -35,6 +28,7 @@ type versions struct {
     PlainContent       string `json:"plain_content"`
     Subject            string `json:"subject"`
     TemplateId         string `json:"template_id"`
+    TestData           string `json:"test_data,omitempty"`
     ThumbnailUrl       string `json:"thumbnail_url"`
 }
 
@@ -52,6 +46,12 @@ func resourceTemplate() *schema.Resource {
                 Type:     schema.TypeString,
                 Required: true,
             },
+            "manage_versions": &schema.Schema{
+                Type:        schema.TypeBool,
+                Optional:    true,
+                Default:     true,
+                Description: "Whether this resource manages the template's versions. Set to false when versions are managed independently with sendgrid_template_version.",
+            },
             "versions": &schema.Schema{
                 Type:     schema.TypeList,
                 Optional: true,
@@ -69,12 +69,14 @@ func resourceTemplate() *schema.Resource {
                             Optional: true,
                         },
                         "subject": &schema.Schema{
-                            Type:     schema.TypeString,
-                            Required: true,
+                            Type:         schema.TypeString,
+                            Required:     true,
+                            ValidateFunc: validateHandlebarsContent,
                         },
                         "html_content": &schema.Schema{
-                            Type:     schema.TypeString,
-                            Optional: true,
+                            Type:         schema.TypeString,
+                            Optional:     true,
+                            ValidateFunc: validateHandlebarsContent,
                         },
                         "plain_content": &schema.Schema{
                             Type:     schema.TypeString,
@@ -104,14 +106,14 @@ func resourceTemplateCreate(d *schema.ResourceData, m interface{}) error {
         return err
     }
 
-    apiKey := m.(*Config).APIKey
-    request := sendgrid.GetRequest(apiKey, "/v3/templates", sendgridAddress)
+    cfg := m.(*Config)
+    request := sendgrid.GetRequest(cfg.APIKey, "/v3/templates", sendgridAddress)
     request.Method = http.MethodPost
     request.Body = data
 
-    res, err := doRequest(request, withStatus(http.StatusCreated), withRateLimit(createTemplateRate))
+    res, err := cfg.doRequest(request, withStatus(http.StatusCreated), withCategory("templates.create"))
     if err != nil {
-        return errors.Wrap(err, "failed to create template")
+        return err
     }
 
     var t template
@@ -122,13 +124,15 @@ func resourceTemplateCreate(d *schema.ResourceData, m interface{}) error {
 
     d.SetId(t.TemplateID)
 
-    items := d.Get("versions").([]interface{})
+    if d.Get("manage_versions").(bool) {
+        items := d.Get("versions").([]interface{})
 
-    for _, item := range items {
-        i := item.(map[string]interface{})
-        setVersions, err := setTemplateVersions(apiKey, d.Id(), i)
-        if err != nil {
-            return err
+        for _, item := range items {
+            i := item.(map[string]interface{})
+            _, err := setTemplateVersions(cfg, d.Id(), i)
+            if err != nil {
+                return err
+            }
         }
     }
 
@@ -143,11 +147,8 @@ func resourceTemplateCreate(d *schema.ResourceData, m interface{}) error {
         MinTimeout:                defaultBackoff,
         ContinuousTargetOccurence: 3,
         Refresh: func() (interface{}, string, error) {
-            template, err := getTemplate(apiKey, d.Id())
-            if l, ok := err.(ratelimitError); ok {
-                time.Sleep(l.timeout)
-                return nil, statusWaiting, nil
-            } else if err != nil {
+            template, err := getTemplate(cfg, d.Id())
+            if err != nil {
                 return nil, "", err
             } else if template == nil {
                 return nil, statusWaiting, nil
@@ -166,8 +167,8 @@ func resourceTemplateCreate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceTemplateRead(d *schema.ResourceData, m interface{}) error {
-    apiKey := m.(*Config).APIKey
-    template, err := getTemplate(apiKey, d.Id())
+    cfg := m.(*Config)
+    template, err := getTemplate(cfg, d.Id())
     if err != nil {
         return err
     } else if template == nil {
@@ -175,13 +176,13 @@ func resourceTemplateRead(d *schema.ResourceData, m interface{}) error {
         return nil
     }
 
-    versionsItems := flattenVersions(template.Versions)
-    if err := d.Set("versions", versionsItems); err != nil {
-        return err
-    }
-
     d.Set("name", template.Name)
-    d.Set("versions", versionsItems)
+
+    if d.Get("manage_versions").(bool) {
+        if err := d.Set("versions", flattenVersions(template.Versions)); err != nil {
+            return err
+        }
+    }
 
     return nil
 }
@@ -197,12 +198,12 @@ func resourceTemplateUpdate(d *schema.ResourceData, m interface{}) error {
         d.SetPartial("name")
     }
 
-    if d.HasChange("versions") {
-        apiKey := m.(*Config).APIKey
+    if d.HasChange("versions") && d.Get("manage_versions").(bool) {
+        cfg := m.(*Config)
         items := d.Get("versions").([]interface{})
         for _, item := range items {
             i := item.(map[string]interface{})
-            updateVersions, err := updateTemplateVersions(apiKey, d.Id(), i)
+            _, err := updateTemplateVersions(cfg, d.Id(), i)
             if err != nil {
                 return err
             }
@@ -217,25 +218,25 @@ func resourceTemplateUpdate(d *schema.ResourceData, m interface{}) error {
 }
 
 func resourceTemplateDelete(d *schema.ResourceData, m interface {}) error {
-    apiKey := m.(*Config).APIKey
-    request := sendgrid.GetRequest(apiKey, "/v3/templates/"+d.Id(), sendgridAddress)
+    cfg := m.(*Config)
+    request := sendgrid.GetRequest(cfg.APIKey, "/v3/templates/"+d.Id(), sendgridAddress)
     request.Method = http.MethodDelete
 
-    res, err := doRequest(request, withStatus(http.StatusNoContent), withRateLimit(deleteTemplateRate), withRetry(5))
-    if err != nil || res.StatusCode != http.StatusOK {
-        return nil
+    _, err := cfg.doRequest(request, withStatus(http.StatusNoContent), withCategory("templates.delete"), withRetry(5))
+    if err != nil {
+        return err
     }
 
-    return errors.Wrap(err, "failed to delete template")
+    return nil
 }
 
-func getTemplate(apiKey, id string) (*template, error) {
-    request := sendgrid.GetRequest(apiKey, "/v3/templates/"+id, sendgridAddress)
+func getTemplate(cfg *Config, id string) (*template, error) {
+    request := sendgrid.GetRequest(cfg.APIKey, "/v3/templates/"+id, sendgridAddress)
     request.Method = http.MethodGet
 
-    res, err := doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound))
+    res, err := cfg.doRequest(request, withStatus(http.StatusOK), withStatus(http.StatusNotFound), withCategory("templates.read"))
     if err != nil {
-        return nil, errors.Wrap(err, "failed to query API template")
+        return nil, err
     }
 
     if res.StatusCode == http.StatusNotFound {
@@ -276,20 +277,20 @@ func updateTemplateName(d *schema.ResourceData, m interface {}) error {
         return err
     }
 
-    apiKey := m.(*Config).APIKey
-    request := sendgrid.GetRequest(apiKey, "/v3/templates/"+d.Id(), sendgridAddress)
+    cfg := m.(*Config)
+    request := sendgrid.GetRequest(cfg.APIKey, "/v3/templates/"+d.Id(), sendgridAddress)
     request.Method = http.MethodPatch
     request.Body = data
 
-    res, err := doRequest(request, withStatus(http.StatusCreated), withRateLimit(createTemplateRate))
-    if err == nil || res.StatusCode != http.StatusOK {
-        return nil
+    _, err = cfg.doRequest(request, withStatus(http.StatusOK), withCategory("templates.update"))
+    if err != nil {
+        return err
     }
 
     return nil
 }
 
-func setTemplateVersions(apiKey string, templateId string, v map[string]interface {}) (string, error) {
+func setTemplateVersions(cfg *Config, templateId string, v map[string]interface {}) (string, error) {
     uri := "/v3/templates/"+templateId+"/versions"
 
     payload := map[string]interface{}{
@@ -306,13 +307,13 @@ func setTemplateVersions(apiKey string, templateId string, v map[string]interfac
         return "", errors.Wrap(err, "Invalid json set template versions")
     }
 
-    request := sendgrid.GetRequest(apiKey, uri, sendgridAddress)
+    request := sendgrid.GetRequest(cfg.APIKey, uri, sendgridAddress)
     request.Method = http.MethodPost
     request.Body = data
 
-    res, err := doRequest(request, withStatus(http.StatusCreated), withRateLimit(deleteTemplateRate), withRetry(5))
+    res, err := cfg.doRequest(request, withStatus(http.StatusCreated), withCategory("templates.versions.create"), withRetry(5))
     if err != nil {
-        return "", errors.Wrap(err, "failed to create template versions")
+        return "", err
     }
 
     var version versions
@@ -324,7 +325,7 @@ func setTemplateVersions(apiKey string, templateId string, v map[string]interfac
     return version.Id, nil
 }
 
-func updateTemplateVersions(apiKey string, templateId string, v map[string]interface {}) (string, error) {
+func updateTemplateVersions(cfg *Config, templateId string, v map[string]interface {}) (string, error) {
     uri := "/v3/templates/"+templateId+"/versions"
 
     payload := map[string]interface{}{
@@ -341,13 +342,13 @@ func updateTemplateVersions(apiKey string, templateId string, v map[string]inter
         return "", errors.Wrap(err, "Invalid json update template versions")
     }
 
-    request := sendgrid.GetRequest(apiKey, uri, sendgridAddress)
+    request := sendgrid.GetRequest(cfg.APIKey, uri, sendgridAddress)
     request.Method = http.MethodPatch
     request.Body = data
 
-    res, err := doRequest(request, withStatus(http.StatusCreated), withRateLimit(deleteTemplateRate), withRetry(5))
+    res, err := cfg.doRequest(request, withStatus(http.StatusCreated), withCategory("templates.versions.update"), withRetry(5))
     if err != nil {
-        return "", errors.Wrap(err, "failed to update template versions")
+        return "", err
     }
 
     var version versions
@@ -355,7 +356,7 @@ func updateTemplateVersions(apiKey string, templateId string, v map[string]inter
     if err != nil {
         return "", errors.Wrap(err, "failed to unmarshal created template ID")
     }
-    
+
     return version.Id, nil
 }
 
@@ -0,0 +1,114 @@
+package sendgrid
+
+import (
+    "fmt"
+    "strings"
+)
+
+// reservedTemplateSubstitutions are names SendGrid reserves for its own
+// dynamic template engine, so they cannot be used as substitution tags.
+var reservedTemplateSubstitutions = map[string]bool{
+    "unsubscribe":      true,
+    "unsubscribe_url":  true,
+    "subscription_url": true,
+}
+
+// allowedHandlebarsBlockHelpers are the only block helpers SendGrid's
+// Handlebars-style templating supports.
+var allowedHandlebarsBlockHelpers = map[string]bool{
+    "each": true,
+    "if":   true,
+}
+
+// validateHandlebarsContent is a schema.SchemaValidateFunc that rejects
+// unbalanced {{ }} / {{{ }}} tags, unknown block helpers and reserved
+// substitution names, so templates fail at `terraform plan` instead of
+// surfacing as a 400 from the SendGrid API during apply.
+func validateHandlebarsContent(i interface{}, k string) ([]string, []error) {
+    v, ok := i.(string)
+    if !ok {
+        return nil, []error{fmt.Errorf("%q must be a string", k)}
+    }
+
+    if err := validateHandlebars(v); err != nil {
+        return nil, []error{fmt.Errorf("%q is invalid: %s", k, err)}
+    }
+
+    return nil, nil
+}
+
+// validateHandlebars scans content for {{ }} and {{{ }}} tags only; any
+// brace that isn't part of one of those delimiters (e.g. literal CSS like
+// ".foo{color:red}") is plain content and is left alone.
+func validateHandlebars(content string) error {
+    var stack []string
+
+    i := 0
+    for i < len(content) {
+        start := strings.Index(content[i:], "{{")
+        if start == -1 {
+            break
+        }
+        start += i
+
+        openLen, closeDelim := 2, "}}"
+        if start+2 < len(content) && content[start+2] == '{' {
+            openLen, closeDelim = 3, "}}}"
+        }
+
+        innerStart := start + openLen
+        closeIdx := strings.Index(content[innerStart:], closeDelim)
+        if closeIdx == -1 {
+            return fmt.Errorf("unbalanced braces")
+        }
+        closeIdx += innerStart
+
+        inner := strings.TrimSpace(content[innerStart:closeIdx])
+        i = closeIdx + len(closeDelim)
+
+        if inner == "" {
+            continue
+        }
+
+        switch {
+        case strings.HasPrefix(inner, "#"):
+            fields := strings.Fields(strings.TrimPrefix(inner, "#"))
+            if len(fields) == 0 {
+                return fmt.Errorf("empty block helper")
+            }
+
+            helper := fields[0]
+            if !allowedHandlebarsBlockHelpers[helper] {
+                return fmt.Errorf("unknown block helper %q", helper)
+            }
+            stack = append(stack, helper)
+        case strings.HasPrefix(inner, "/"):
+            helper := strings.TrimPrefix(inner, "/")
+            if helper == "" {
+                return fmt.Errorf("empty closing block helper")
+            }
+            if !allowedHandlebarsBlockHelpers[helper] {
+                return fmt.Errorf("unknown block helper %q", helper)
+            }
+            if len(stack) == 0 || stack[len(stack)-1] != helper {
+                return fmt.Errorf("mismatched closing helper %q", helper)
+            }
+            stack = stack[:len(stack)-1]
+        case inner == "else":
+            if len(stack) == 0 {
+                return fmt.Errorf("{{else}} used outside of a block helper")
+            }
+        default:
+            fields := strings.Fields(inner)
+            if len(fields) > 0 && reservedTemplateSubstitutions[fields[0]] {
+                return fmt.Errorf("%q is a reserved substitution name", fields[0])
+            }
+        }
+    }
+
+    if len(stack) != 0 {
+        return fmt.Errorf("unclosed block helper %q", stack[len(stack)-1])
+    }
+
+    return nil
+}